@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminAPI exposes runtime control over a Chain's plugins: a way to
+// enable/disable them without a restart, and a way to read back which
+// plugins are currently live. pkg/status isn't reachable from this package
+// (it lives above internal/ and would need to import the concrete chain a
+// deployment wires up), so the read path is a plain GET here instead.
+type AdminAPI struct {
+	chain *Chain
+}
+
+// NewAdminAPI returns an AdminAPI managing the given chain.
+func NewAdminAPI(chain *Chain) *AdminAPI {
+	return &AdminAPI{chain: chain}
+}
+
+// RegisterEndpoints mounts the authorizer admin routes on router.
+func (a *AdminAPI) RegisterEndpoints(router *chi.Mux) {
+	router.Get("/api/v0/authorizers", a.handleStatus)
+	router.Post("/api/v0/authorizers/{name}/enable", a.handleEnable)
+	router.Post("/api/v0/authorizers/{name}/disable", a.handleDisable)
+}
+
+func (a *AdminAPI) handleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a.chain.Status())
+}
+
+func (a *AdminAPI) handleEnable(w http.ResponseWriter, r *http.Request) {
+	a.toggle(w, r, a.chain.Enable)
+}
+
+func (a *AdminAPI) handleDisable(w http.ResponseWriter, r *http.Request) {
+	a.toggle(w, r, a.chain.Disable)
+}
+
+func (a *AdminAPI) toggle(w http.ResponseWriter, r *http.Request, action func(string) error) {
+	name := chi.URLParam(r, "name")
+
+	if err := action(name); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a.chain.Status())
+}
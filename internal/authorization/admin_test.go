@@ -0,0 +1,92 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newTestAdminAPI() (*AdminAPI, *chi.Mux) {
+	chain := NewChain(map[string]Authorizer{"openfga": &stubAuthorizer{allowed: true}}, []string{"openfga"})
+	api := NewAdminAPI(chain)
+
+	router := chi.NewMux()
+	api.RegisterEndpoints(router)
+
+	return api, router
+}
+
+func TestAdminAPIDisableThenEnable(t *testing.T) {
+	api, router := newTestAdminAPI()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v0/authorizers/openfga/disable", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("disable: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	if api.chain.Status()[0].Enabled {
+		t.Error("chain still enabled after disable request")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v0/authorizers/openfga/enable", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("enable: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	if !api.chain.Status()[0].Enabled {
+		t.Error("chain still disabled after enable request")
+	}
+}
+
+func TestAdminAPIUnknownAuthorizerReturnsNotFound(t *testing.T) {
+	_, router := newTestAdminAPI()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v0/authorizers/missing/disable", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want %q", got, "application/json")
+	}
+}
+
+func TestAdminAPIStatusReportsPluginState(t *testing.T) {
+	api, router := newTestAdminAPI()
+
+	if err := api.chain.Disable("openfga"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/authorizers", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var statuses []PluginStatus
+	if err := json.NewDecoder(rec.Body).Decode(&statuses); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+
+	if len(statuses) != 1 || statuses[0].Name != "openfga" || statuses[0].Enabled {
+		t.Errorf("Status() = %+v, want a single disabled openfga entry", statuses)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Authorizer is implemented by anything capable of deciding whether a
+// subject may perform a relation on an object (OpenFGA, a local RBAC store,
+// an external HTTP sidecar, ...).
+type Authorizer interface {
+	Check(ctx context.Context, subject, relation, object string) (bool, error)
+}
+
+// chainLink pairs a named Authorizer plugin with whether it's currently
+// taking part in authorization decisions.
+type chainLink struct {
+	name     string
+	enabled  bool
+	delegate Authorizer
+}
+
+// Chain is an ordered list of named Authorizer plugins that itself
+// implements Authorizer, so it can be handed to authorization.NewMiddleware
+// exactly like a single authorizer. Disabled plugins are skipped entirely -
+// no Check call, no dial attempt - so toggling one off takes effect for the
+// very next request.
+type Chain struct {
+	mu    sync.RWMutex
+	order []string
+	links map[string]*chainLink
+}
+
+// NewChain builds a Chain with all the given plugins enabled, evaluated in
+// the order they're passed in.
+func NewChain(plugins map[string]Authorizer, order []string) *Chain {
+	links := make(map[string]*chainLink, len(plugins))
+
+	for name, delegate := range plugins {
+		links[name] = &chainLink{name: name, enabled: true, delegate: delegate}
+	}
+
+	return &Chain{order: order, links: links}
+}
+
+// Check runs the subject/relation/object tuple through every enabled
+// authorizer in order, short-circuiting on the first that grants access.
+// A plugin that has been disabled is skipped without being invoked. The lock
+// is released before any plugin is called, so a slow plugin never holds up
+// Enable/Disable or other requests.
+func (c *Chain) Check(ctx context.Context, subject, relation, object string) (bool, error) {
+	c.mu.RLock()
+	active := make([]*chainLink, 0, len(c.order))
+	for _, name := range c.order {
+		link, ok := c.links[name]
+		if !ok || !link.enabled {
+			continue
+		}
+
+		active = append(active, link)
+	}
+	c.mu.RUnlock()
+
+	for _, link := range active {
+		allowed, err := link.delegate.Check(ctx, subject, relation, object)
+		if err != nil {
+			return false, fmt.Errorf("authorizer %q: %w", link.name, err)
+		}
+
+		if allowed {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Enable puts a previously disabled plugin back into the active chain.
+func (c *Chain) Enable(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	link, ok := c.links[name]
+	if !ok {
+		return fmt.Errorf("authorizer %q not found", name)
+	}
+
+	link.enabled = true
+
+	return nil
+}
+
+// Disable removes a plugin from the active chain. In-flight calls that
+// already picked it aren't interrupted, but no new request will reach it -
+// Check skips disabled links without calling the delegate.
+func (c *Chain) Disable(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	link, ok := c.links[name]
+	if !ok {
+		return fmt.Errorf("authorizer %q not found", name)
+	}
+
+	link.enabled = false
+
+	return nil
+}
+
+// PluginStatus is the status of a single authorizer plugin within the chain.
+type PluginStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Status reports the live/disabled state of every plugin in the chain, in
+// evaluation order, for surfacing through the status API.
+func (c *Chain) Status() []PluginStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	statuses := make([]PluginStatus, 0, len(c.order))
+	for _, name := range c.order {
+		link := c.links[name]
+		statuses = append(statuses, PluginStatus{Name: link.name, Enabled: link.enabled})
+	}
+
+	return statuses
+}
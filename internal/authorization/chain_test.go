@@ -0,0 +1,108 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubAuthorizer struct {
+	allowed bool
+	err     error
+	calls   int
+}
+
+func (s *stubAuthorizer) Check(ctx context.Context, subject, relation, object string) (bool, error) {
+	s.calls++
+	return s.allowed, s.err
+}
+
+func TestChainCheckSkipsDisabledLinks(t *testing.T) {
+	first := &stubAuthorizer{allowed: false}
+	second := &stubAuthorizer{allowed: true}
+
+	chain := NewChain(map[string]Authorizer{"first": first, "second": second}, []string{"first", "second"})
+
+	if err := chain.Disable("second"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+
+	allowed, err := chain.Check(context.Background(), "user", "can_view", "doc:1")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if allowed {
+		t.Errorf("Check() = true, want false with second authorizer disabled")
+	}
+
+	if second.calls != 0 {
+		t.Errorf("disabled authorizer was called %d times, want 0", second.calls)
+	}
+}
+
+func TestChainCheckShortCircuitsOnFirstAllow(t *testing.T) {
+	first := &stubAuthorizer{allowed: true}
+	second := &stubAuthorizer{allowed: false}
+
+	chain := NewChain(map[string]Authorizer{"first": first, "second": second}, []string{"first", "second"})
+
+	allowed, err := chain.Check(context.Background(), "user", "can_view", "doc:1")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if !allowed {
+		t.Errorf("Check() = false, want true")
+	}
+
+	if second.calls != 0 {
+		t.Errorf("second authorizer was called %d times, want 0 (short-circuited)", second.calls)
+	}
+}
+
+func TestChainCheckPropagatesError(t *testing.T) {
+	first := &stubAuthorizer{err: errors.New("boom")}
+
+	chain := NewChain(map[string]Authorizer{"first": first}, []string{"first"})
+
+	if _, err := chain.Check(context.Background(), "user", "can_view", "doc:1"); err == nil {
+		t.Error("Check() error = nil, want non-nil")
+	}
+}
+
+func TestChainEnableDisableUnknownName(t *testing.T) {
+	chain := NewChain(map[string]Authorizer{"first": &stubAuthorizer{}}, []string{"first"})
+
+	if err := chain.Enable("missing"); err == nil {
+		t.Error("Enable(missing) error = nil, want non-nil")
+	}
+
+	if err := chain.Disable("missing"); err == nil {
+		t.Error("Disable(missing) error = nil, want non-nil")
+	}
+}
+
+func TestChainStatusReflectsToggles(t *testing.T) {
+	chain := NewChain(map[string]Authorizer{"first": &stubAuthorizer{}, "second": &stubAuthorizer{}}, []string{"first", "second"})
+
+	if err := chain.Disable("first"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+
+	statuses := chain.Status()
+	if len(statuses) != 2 {
+		t.Fatalf("Status() returned %d entries, want 2", len(statuses))
+	}
+
+	if statuses[0].Name != "first" || statuses[0].Enabled {
+		t.Errorf("Status()[0] = %+v, want {first false}", statuses[0])
+	}
+
+	if statuses[1].Name != "second" || !statuses[1].Enabled {
+		t.Errorf("Status()[1] = %+v, want {second true}", statuses[1])
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package timeout provides a per-route request timeout middleware so a stuck
+// upstream call (Kratos, Hydra, OpenFGA, ...) can't tie up a worker
+// indefinitely.
+package timeout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/identity-platform-admin-ui/internal/problem"
+)
+
+// Timeout returns middleware that bounds every request to defaultTimeout,
+// unless its path matches one of the entries in overrides - keyed by path
+// prefix, e.g. "/api/v0/schemas" - in which case the longest matching prefix
+// wins. On expiry it writes a 504 problem+json response, provided the
+// handler hasn't already started writing one, and records the cancellation
+// on the request's tracer span.
+func Timeout(defaultTimeout time.Duration, overrides map[string]time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d := resolve(r.URL.Path, defaultTimeout, overrides)
+
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{ResponseWriter: w}
+			done := make(chan struct{})
+
+			go func() {
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-ctx.Done():
+				alreadyWrote := tw.markTimedOut()
+				recordCancellation(ctx, d)
+
+				if !alreadyWrote {
+					writeTimeout(w, d)
+				}
+			}
+		})
+	}
+}
+
+// resolve picks the longest overrides prefix matching path, falling back to
+// defaultTimeout when nothing matches.
+func resolve(path string, defaultTimeout time.Duration, overrides map[string]time.Duration) time.Duration {
+	timeout := defaultTimeout
+	longest := ""
+
+	for prefix, d := range overrides {
+		if strings.HasPrefix(path, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+			timeout = d
+		}
+	}
+
+	return timeout
+}
+
+func recordCancellation(ctx context.Context, d time.Duration) {
+	span := trace.SpanFromContext(ctx)
+	span.SetStatus(codes.Error, "request exceeded its timeout")
+	span.SetAttributes(attribute.Float64("timeout.seconds", d.Seconds()))
+}
+
+func writeTimeout(w http.ResponseWriter, d time.Duration) {
+	problem.Problem{
+		Type:   "about:blank",
+		Title:  "Request timed out",
+		Status: http.StatusGatewayTimeout,
+		Detail: fmt.Sprintf("handler did not complete within %s", d),
+	}.Write(w)
+}
+
+// timeoutWriter passes header/body writes straight through to the wrapped
+// ResponseWriter until the deadline, then rejects them, so a handler
+// goroutine that's still running after the deadline can't write to the
+// ResponseWriter once the 504 has already gone out.
+type timeoutWriter struct {
+	http.ResponseWriter
+
+	mu          sync.Mutex
+	timedOut    bool
+	wroteHeader bool
+}
+
+// markTimedOut flags the writer as timed out and reports whether a header
+// had already gone out before it did.
+func (tw *timeoutWriter) markTimedOut() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.timedOut = true
+
+	return tw.wroteHeader
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+
+	tw.wroteHeader = true
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	if !tw.wroteHeader {
+		tw.wroteHeader = true
+		tw.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+
+	return tw.ResponseWriter.Write(b)
+}
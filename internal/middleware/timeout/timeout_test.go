@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package timeout
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutAllowsFastHandler(t *testing.T) {
+	handler := Timeout(50*time.Millisecond, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/status", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestTimeoutCancelsSlowHandler(t *testing.T) {
+	handler := Timeout(10*time.Millisecond, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/identities", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusGatewayTimeout)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", ct)
+	}
+}
+
+func TestResolvePicksLongestMatchingPrefix(t *testing.T) {
+	overrides := map[string]time.Duration{
+		"/api/v0":         5 * time.Second,
+		"/api/v0/schemas": 30 * time.Second,
+	}
+
+	got := resolve("/api/v0/schemas/default", time.Second, overrides)
+	if got != 30*time.Second {
+		t.Errorf("resolve() = %s, want %s", got, 30*time.Second)
+	}
+}
+
+func TestResolveFallsBackToDefault(t *testing.T) {
+	got := resolve("/api/v0/status", time.Second, map[string]time.Duration{"/api/v0/schemas": 30 * time.Second})
+	if got != time.Second {
+		t.Errorf("resolve() = %s, want default %s", got, time.Second)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package pool provides a fixed-size worker pool for work that a request
+// handler wants to enqueue and return without waiting on, while still
+// honoring the context the request was bound by (e.g. the per-route timeout
+// in internal/middleware/timeout) - so a cancelled request can't leave queued
+// work running past its deadline.
+package pool
+
+import (
+	"context"
+)
+
+// Job is a unit of work submitted to a WorkerPoolInterface. It's handed the
+// context it was submitted with, not the one active when a worker finally
+// picks it up, so it can check ctx.Err() itself before doing anything
+// expensive.
+type Job func(ctx context.Context)
+
+// WorkerPoolInterface queues a Job for asynchronous execution. Implementations
+// are expected to drop a Job without running it if ctx is already done by the
+// time a worker is free, so a cancelled/timed-out request can't keep queued
+// work alive past its deadline.
+type WorkerPoolInterface interface {
+	Submit(ctx context.Context, job Job)
+}
+
+type queuedJob struct {
+	ctx context.Context
+	job Job
+}
+
+// Pool is a WorkerPoolInterface backed by a fixed number of worker
+// goroutines reading off a shared, bounded queue.
+type Pool struct {
+	queue chan queuedJob
+	done  chan struct{}
+}
+
+// NewPool starts size worker goroutines reading off a queue of queueSize
+// pending jobs, and returns the Pool accepting work on it. Call Close to
+// stop the workers once the pool is no longer needed.
+func NewPool(size, queueSize int) *Pool {
+	p := &Pool{
+		queue: make(chan queuedJob, queueSize),
+		done:  make(chan struct{}),
+	}
+
+	for i := 0; i < size; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+// Submit enqueues job to run on a worker goroutine. If ctx is already done
+// by the time a worker dequeues it, the job is dropped without running -
+// callers that need to know this happened should check ctx themselves (e.g.
+// via a context value) rather than relying on job's own return, since Job
+// has none.
+func (p *Pool) Submit(ctx context.Context, job Job) {
+	select {
+	case p.queue <- queuedJob{ctx: ctx, job: job}:
+	case <-p.done:
+	}
+}
+
+// Close stops accepting new work and lets in-flight jobs finish; queued jobs
+// that haven't started yet are left unrun.
+func (p *Pool) Close() {
+	close(p.done)
+}
+
+func (p *Pool) work() {
+	for {
+		select {
+		case <-p.done:
+			return
+		case qj := <-p.queue:
+			// select picks at random when both cases are ready, so check done
+			// again to keep queued jobs from starting after Close.
+			select {
+			case <-p.done:
+				return
+			default:
+			}
+
+			if qj.ctx.Err() != nil {
+				continue
+			}
+
+			qj.job(qj.ctx)
+		}
+	}
+}
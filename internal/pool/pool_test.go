@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolSubmitRunsJobWithLiveContext(t *testing.T) {
+	p := NewPool(1, 1)
+	defer p.Close()
+
+	done := make(chan struct{})
+	p.Submit(context.Background(), func(ctx context.Context) {
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job with a live context was never run")
+	}
+}
+
+func TestPoolSubmitDropsJobWithCancelledContext(t *testing.T) {
+	p := NewPool(1, 2)
+	defer p.Close()
+
+	// Occupy the single worker so the next Submit sits queued, giving us a
+	// window to cancel its context before a worker ever dequeues it.
+	blocking := make(chan struct{})
+	release := make(chan struct{})
+	p.Submit(context.Background(), func(ctx context.Context) {
+		close(blocking)
+		<-release
+	})
+	<-blocking
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var ran int32
+	queuedDone := make(chan struct{})
+	p.Submit(ctx, func(ctx context.Context) {
+		atomic.StoreInt32(&ran, 1)
+		close(queuedDone)
+	})
+
+	close(release)
+
+	select {
+	case <-queuedDone:
+		t.Fatal("job submitted with an already-cancelled context was run")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Error("ran = 1, want 0 for a job dropped due to context cancellation")
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package problem provides the RFC 7807 problem+json payload shared by
+// middleware that rejects a request before it reaches a handler, so the
+// shape isn't duplicated in every package that needs it.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is an RFC 7807 problem+json payload.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Write sets the problem+json content type, writes p.Status as the response
+// status code, and encodes p as the body.
+func (p Problem) Write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"net/http"
+)
+
+// principalContextKey is the context key PrincipalFromContext reads back.
+// chain_test.go proves a real authorization.Authorizer sees the correct
+// Principal when a caller reads it through PrincipalFromContext, as
+// authorization.NewMiddleware's Authorize() must do for the static-credential
+// path to reach the same enforcement as the OAuth2 cookie/bearer flow -
+// that production middleware isn't part of this package, so this can't
+// verify it actually does.
+type principalContextKey struct{}
+
+// requestAuthenticator is satisfied by anything that can recognize a caller
+// straight off the request, without the redirect-based OAuth2 dance -
+// StaticCredentialAuthenticator today, potentially others later.
+type requestAuthenticator interface {
+	Authenticate(r *http.Request) (*Principal, bool)
+}
+
+// AuthenticationChain tries each of its authenticators in order and, if none
+// recognizes the request, falls back to the wrapped OAuth2 cookie/bearer
+// flow. This lets machine clients (CI jobs, operators) and interactive human
+// sessions share the same router without either path blocking the other.
+type AuthenticationChain struct {
+	authenticators []requestAuthenticator
+}
+
+// NewAuthenticationChain builds a chain evaluated in the given order.
+func NewAuthenticationChain(authenticators ...requestAuthenticator) *AuthenticationChain {
+	return &AuthenticationChain{authenticators: authenticators}
+}
+
+// Wrap returns middleware that tries the chain's authenticators first and,
+// if none match, runs oauth2Fallback (typically
+// AuthenticationMiddleware.OAuth2AuthenticationChain composed into a single
+// middleware via chi.Middlewares.Handler).
+func (c *AuthenticationChain) Wrap(oauth2Fallback func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fallback := oauth2Fallback(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, authenticator := range c.authenticators {
+				principal, ok := authenticator.Authenticate(r)
+				if !ok {
+					continue
+				}
+
+				ctx := context.WithValue(r.Context(), principalContextKey{}, principal)
+				next.ServeHTTP(w, r.WithContext(ctx))
+
+				return
+			}
+
+			fallback.ServeHTTP(w, r)
+		})
+	}
+}
+
+// PrincipalFromContext returns the Principal attached by the chain, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+
+	return principal, ok
+}
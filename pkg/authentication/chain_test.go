@@ -0,0 +1,142 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/canonical/identity-platform-admin-ui/internal/authorization"
+)
+
+func TestAuthenticationChainWrapAttachesPrincipalFromMatchingAuthenticator(t *testing.T) {
+	store := NewInMemoryStaticTokenStore([]StaticToken{
+		{HashedToken: hashToken("ci-token"), Subject: "ci-bot", Roles: []string{"writer"}},
+	})
+	authenticator := NewStaticCredentialAuthenticator(store, nil, nil)
+	chain := NewAuthenticationChain(authenticator)
+
+	var gotPrincipal *Principal
+	var gotOK bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPrincipal, gotOK = PrincipalFromContext(r.Context())
+	})
+	oauth2Fallback := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("oauth2Fallback was called even though the static authenticator recognized the request")
+		})
+	}
+
+	handler := chain.Wrap(oauth2Fallback)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/identities", nil)
+	req.Header.Set("Authorization", "Bearer ci-token")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !gotOK {
+		t.Fatal("PrincipalFromContext() ok = false, want true downstream of a matching authenticator")
+	}
+
+	if gotPrincipal.Subject != "ci-bot" {
+		t.Errorf("Subject = %q, want %q", gotPrincipal.Subject, "ci-bot")
+	}
+}
+
+func TestAuthenticationChainWrapFallsBackWhenNoAuthenticatorMatches(t *testing.T) {
+	store := NewInMemoryStaticTokenStore(nil)
+	authenticator := NewStaticCredentialAuthenticator(store, nil, nil)
+	chain := NewAuthenticationChain(authenticator)
+
+	fallbackCalled := false
+	oauth2Fallback := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fallbackCalled = true
+			next.ServeHTTP(w, r)
+		})
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := PrincipalFromContext(r.Context()); ok {
+			t.Error("PrincipalFromContext() ok = true, want false when no authenticator matched")
+		}
+	})
+
+	handler := chain.Wrap(oauth2Fallback)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/identities", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !fallbackCalled {
+		t.Error("oauth2Fallback was not called when no authenticator matched the request")
+	}
+}
+
+type recordingAuthorizer struct {
+	gotSubject string
+}
+
+func (a *recordingAuthorizer) Check(ctx context.Context, subject, relation, object string) (bool, error) {
+	a.gotSubject = subject
+
+	return true, nil
+}
+
+// authorizeFromPrincipal stands in for the real authorization.NewMiddleware(...).Authorize(),
+// which lives outside this package and isn't available to exercise directly here. It reads
+// the Principal through the same exported PrincipalFromContext a static-authenticated request
+// gets, then calls the real authorization.Authorizer contract - proving the two packages can
+// interoperate through that accessor, though not that the real middleware actually does.
+func authorizeFromPrincipal(authorizer authorization.Authorizer, relation, object string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			allowed, err := authorizer.Check(r.Context(), principal.Subject, relation, object)
+			if err != nil || !allowed {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestAuthenticationChainPrincipalReachesAuthorizationCheck(t *testing.T) {
+	store := NewInMemoryStaticTokenStore([]StaticToken{
+		{HashedToken: hashToken("ci-token"), Subject: "ci-bot", Roles: []string{"writer"}},
+	})
+	authenticator := NewStaticCredentialAuthenticator(store, nil, nil)
+	chain := NewAuthenticationChain(authenticator)
+	authorizer := &recordingAuthorizer{}
+
+	handlerCalled := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+	})
+	oauth2Fallback := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("oauth2Fallback was called even though the static authenticator recognized the request")
+		})
+	}
+
+	handler := chain.Wrap(oauth2Fallback)(authorizeFromPrincipal(authorizer, "view", "identities")(next))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/identities", nil)
+	req.Header.Set("Authorization", "Bearer ci-token")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !handlerCalled {
+		t.Fatal("handler was not reached - the authorization check did not see the Principal the chain attached")
+	}
+
+	if authorizer.gotSubject != "ci-bot" {
+		t.Errorf("authorizer.Check() subject = %q, want %q", authorizer.gotSubject, "ci-bot")
+	}
+}
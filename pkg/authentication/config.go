@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+// Config holds the settings NewRouter needs to wire up OAuth2/OIDC
+// authentication, independent of which identity provider is in play.
+type Config struct {
+	Enabled                     bool
+	Provider                    string
+	IssuerURL                   string
+	ClientID                    string
+	ClientSecret                string
+	RedirectURL                 string
+	CookiesEncryptionKey        string
+	AuthCookieTTLSeconds        int
+	UserSessionCookieTTLSeconds int
+	// OfflineAccess requests the offline_access scope from providers that
+	// support it (e.g. Keycloak), so the refresh token stays valid past the
+	// user's SSO session.
+	OfflineAccess bool
+	// RefreshSkewSeconds is how far ahead of expiry TokenRefresher rotates an
+	// access token.
+	RefreshSkewSeconds int
+	// RevocationStoreDSN selects a shared RevokedTokenStore backend for
+	// multi-replica deployments (e.g. a Redis DSN). Empty keeps the default
+	// NewInMemoryRevokedTokenStore, which is correct for a single replica only.
+	RevocationStoreDSN string
+	// StaticTokens lets machine clients (CI jobs, operators) authenticate with
+	// a static bearer token instead of the interactive OAuth2 flow. Empty
+	// disables the StaticCredentialAuthenticator entirely.
+	StaticTokens []StaticToken
+}
+
+// NewConfig returns a Config for an OIDC provider selected by providerName
+// (see providers.Generic, providers.Keycloak); an empty providerName falls
+// back to generic OIDC.
+func NewConfig(providerName, issuerURL, clientID, clientSecret, redirectURL, cookiesEncryptionKey string, authCookieTTLSeconds, userSessionCookieTTLSeconds, refreshSkewSeconds int, offlineAccess bool, revocationStoreDSN string, staticTokens []StaticToken) *Config {
+	return &Config{
+		Enabled:                     true,
+		Provider:                    providerName,
+		IssuerURL:                   issuerURL,
+		ClientID:                    clientID,
+		ClientSecret:                clientSecret,
+		RedirectURL:                 redirectURL,
+		CookiesEncryptionKey:        cookiesEncryptionKey,
+		AuthCookieTTLSeconds:        authCookieTTLSeconds,
+		UserSessionCookieTTLSeconds: userSessionCookieTTLSeconds,
+		RefreshSkewSeconds:          refreshSkewSeconds,
+		OfflineAccess:               offlineAccess,
+		RevocationStoreDSN:          revocationStoreDSN,
+		StaticTokens:                staticTokens,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"net/http"
+
+	"github.com/canonical/identity-platform-admin-ui/internal/logging"
+	"github.com/canonical/identity-platform-admin-ui/internal/tracing"
+)
+
+// LogoutHandler clears the caller's session cookies, records the access
+// token as revoked so it's rejected immediately across replicas, and - when
+// the configured provider exposes one - redirects to its end-session
+// endpoint so the IdP-side session ends too.
+type LogoutHandler struct {
+	oauth2Context OAuth2ContextInterface
+	cookieManager AuthCookieManagerInterface
+	revoked       RevokedTokenStore
+	tracer        tracing.TracingInterface
+	logger        logging.LoggerInterface
+}
+
+// NewLogoutHandler returns a handler for "/api/v0/auth/logout".
+func NewLogoutHandler(oauth2Context OAuth2ContextInterface, cookieManager AuthCookieManagerInterface, revoked RevokedTokenStore, tracer tracing.TracingInterface, logger logging.LoggerInterface) *LogoutHandler {
+	return &LogoutHandler{
+		oauth2Context: oauth2Context,
+		cookieManager: cookieManager,
+		revoked:       revoked,
+		tracer:        tracer,
+		logger:        logger,
+	}
+}
+
+func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "authentication.LogoutHandler.ServeHTTP")
+	defer span.End()
+
+	if token, err := h.cookieManager.AuthCookie(r); err == nil && token != nil {
+		if err := h.revoked.Revoke(ctx, token.AccessToken, token.Expiry); err != nil {
+			h.logger.Errorf("failed to record revoked token on logout: %v", err)
+		}
+	}
+
+	h.cookieManager.ClearCookies(w)
+
+	if logoutURL := h.oauth2Context.LogoutURL(); logoutURL != "" {
+		http.Redirect(w, r, logoutURL, http.StatusFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/canonical/identity-platform-admin-ui/internal/logging"
+	"github.com/canonical/identity-platform-admin-ui/internal/monitoring"
+	"github.com/canonical/identity-platform-admin-ui/internal/tracing"
+	"github.com/canonical/identity-platform-admin-ui/pkg/authentication/providers"
+)
+
+// OAuth2ContextInterface is the subset of the OIDC/OAuth2 login flow the
+// router, TokenRefresher and LogoutHandler need, independent of which
+// identity provider config.Provider selects.
+type OAuth2ContextInterface interface {
+	// Config returns the oauth2.Config built from the discovered provider
+	// endpoints and the configured client credentials/scopes.
+	Config() *oauth2.Config
+	// AuthCodeURL returns the URL to redirect the browser to for login.
+	AuthCodeURL(state string) string
+	// Exchange trades an authorization code for a token pair, running the
+	// provider's PostLogin hook on success.
+	Exchange(ctx context.Context, code string) (*oauth2.Token, error)
+	// Principal verifies token's ID token and maps its claims onto a
+	// Principal via the configured provider.
+	Principal(ctx context.Context, token *oauth2.Token) (*Principal, error)
+	// LogoutURL returns the provider's end-session endpoint, or "" if it
+	// doesn't have one and local cookie clearing is sufficient.
+	LogoutURL() string
+}
+
+// OAuth2Context is the default OAuth2ContextInterface implementation: it
+// drives the oauth2.Config built from the discovered OIDC provider, and
+// delegates every provider-specific decision (scopes, logout, claim
+// mapping) to the providers.Provider it was built with.
+type OAuth2Context struct {
+	provider     providers.Provider
+	oauth2Config *oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+
+	tracer  tracing.TracingInterface
+	logger  logging.LoggerInterface
+	monitor monitoring.MonitorInterface
+}
+
+// NewOAuth2Context discovers provider's issuer via newOIDCProvider (typically
+// oidc.NewProvider) and builds the oauth2.Config used to drive login,
+// requesting "openid" plus whatever provider.DefaultScopes() returns.
+func NewOAuth2Context(config *Config, provider providers.Provider, newOIDCProvider func(ctx context.Context, issuer string) (*oidc.Provider, error), tracer tracing.TracingInterface, logger logging.LoggerInterface, monitor monitoring.MonitorInterface) *OAuth2Context {
+	ctx := context.Background()
+
+	oauth2Config := &oauth2.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		RedirectURL:  config.RedirectURL,
+		Scopes:       append([]string{oidc.ScopeOpenID}, provider.DefaultScopes()...),
+	}
+
+	oidcProvider, err := newOIDCProvider(ctx, provider.DiscoveryURL())
+	if err != nil {
+		logger.Errorf("failed to discover OIDC provider at %s: %v", provider.DiscoveryURL(), err)
+
+		return &OAuth2Context{provider: provider, oauth2Config: oauth2Config, tracer: tracer, logger: logger, monitor: monitor}
+	}
+
+	oauth2Config.Endpoint = oidcProvider.Endpoint()
+
+	return &OAuth2Context{
+		provider:     provider,
+		oauth2Config: oauth2Config,
+		verifier:     oidcProvider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+		tracer:       tracer,
+		logger:       logger,
+		monitor:      monitor,
+	}
+}
+
+// Config returns the oauth2.Config driving login/refresh.
+func (c *OAuth2Context) Config() *oauth2.Config {
+	return c.oauth2Config
+}
+
+// AuthCodeURL returns the URL to redirect the browser to for login.
+func (c *OAuth2Context) AuthCodeURL(state string) string {
+	return c.oauth2Config.AuthCodeURL(state)
+}
+
+// Exchange trades code for a token pair and runs the provider's PostLogin
+// hook (e.g. nothing for generic OIDC, a vendor-specific step for others).
+func (c *OAuth2Context) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	ctx, span := c.tracer.Start(ctx, "authentication.OAuth2Context.Exchange")
+	defer span.End()
+
+	token, err := c.oauth2Config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	if err := c.provider.PostLogin(ctx, token); err != nil {
+		c.logger.Errorf("provider post-login hook failed: %v", err)
+	}
+
+	return token, nil
+}
+
+// Principal verifies token's ID token against the discovered provider and
+// maps its claims onto a Principal via provider.MapClaims, so realm/client
+// roles make it into the session.
+func (c *OAuth2Context) Principal(ctx context.Context, token *oauth2.Token) (*Principal, error) {
+	if c.verifier == nil {
+		return nil, fmt.Errorf("oidc provider was not discovered; cannot verify id_token")
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("token response did not include an id_token")
+	}
+
+	idToken, err := c.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id_token: %w", err)
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id_token claims: %w", err)
+	}
+
+	mapped := c.provider.MapClaims(claims)
+
+	return &Principal{Subject: mapped.Subject, Email: mapped.Email, Roles: mapped.Roles}, nil
+}
+
+// LogoutURL returns the provider's end-session endpoint, or "" if it
+// doesn't have one.
+func (c *OAuth2Context) LogoutURL() string {
+	return c.provider.LogoutURL()
+}
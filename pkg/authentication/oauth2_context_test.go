@@ -0,0 +1,28 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"testing"
+
+	"github.com/canonical/identity-platform-admin-ui/pkg/authentication/providers"
+)
+
+func TestOAuth2ContextLogoutURLDelegatesToProvider(t *testing.T) {
+	provider := providers.New(providers.Keycloak, "https://keycloak.example.com/realms/admin", "admin-ui", false)
+	ctx := &OAuth2Context{provider: provider}
+
+	want := "https://keycloak.example.com/realms/admin/protocol/openid-connect/logout"
+	if got := ctx.LogoutURL(); got != want {
+		t.Errorf("LogoutURL() = %q, want %q", got, want)
+	}
+}
+
+func TestOAuth2ContextPrincipalWithoutVerifierErrors(t *testing.T) {
+	ctx := &OAuth2Context{provider: providers.New(providers.Generic, "https://issuer.example.com", "admin-ui", false)}
+
+	if _, err := ctx.Principal(nil, nil); err == nil {
+		t.Error("Principal() error = nil, want non-nil when the OIDC provider was never discovered")
+	}
+}
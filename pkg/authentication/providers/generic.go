@@ -0,0 +1,51 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package providers
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+)
+
+// GenericProvider is a plain OIDC provider with no vendor-specific behavior:
+// no extra scopes, no dedicated logout endpoint, claims mapped 1:1.
+type GenericProvider struct {
+	issuerURL string
+}
+
+// NewGenericProvider returns a Provider for any spec-compliant OIDC issuer.
+func NewGenericProvider(issuerURL string) *GenericProvider {
+	return &GenericProvider{issuerURL: issuerURL}
+}
+
+func (p *GenericProvider) DiscoveryURL() string {
+	return p.issuerURL
+}
+
+func (p *GenericProvider) DefaultScopes() []string {
+	return []string{"profile", "email"}
+}
+
+func (p *GenericProvider) LogoutURL() string {
+	return ""
+}
+
+func (p *GenericProvider) PostLogin(ctx context.Context, token *oauth2.Token) error {
+	return nil
+}
+
+func (p *GenericProvider) MapClaims(claims map[string]interface{}) *Principal {
+	principal := &Principal{}
+
+	if sub, ok := claims["sub"].(string); ok {
+		principal.Subject = sub
+	}
+
+	if email, ok := claims["email"].(string); ok {
+		principal.Email = email
+	}
+
+	return principal
+}
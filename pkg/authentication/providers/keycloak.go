@@ -0,0 +1,124 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// KeycloakProvider adapts a Keycloak realm to the Provider interface: it
+// requests offline_access when built WithOfflineAccess(true), so the refresh
+// token stays valid past the user's SSO session, drives Keycloak's own
+// logout endpoint, and understands
+// Keycloak's nested realm_access/resource_access role claims.
+type KeycloakProvider struct {
+	issuerURL     string
+	clientName    string
+	offlineAccess bool
+}
+
+// KeycloakOption configures a KeycloakProvider at construction time.
+type KeycloakOption func(*KeycloakProvider)
+
+// WithOfflineAccess requests the offline_access scope so the refresh token
+// returned by Keycloak remains valid after the user's SSO session ends.
+func WithOfflineAccess(enabled bool) KeycloakOption {
+	return func(p *KeycloakProvider) {
+		p.offlineAccess = enabled
+	}
+}
+
+// NewKeycloakProvider returns a Provider for the Keycloak realm reachable at
+// issuerURL (e.g. "https://keycloak.example.com/realms/admin"), scoping
+// extracted client roles to clientName.
+func NewKeycloakProvider(issuerURL, clientName string, opts ...KeycloakOption) *KeycloakProvider {
+	p := &KeycloakProvider{issuerURL: issuerURL, clientName: clientName}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *KeycloakProvider) DiscoveryURL() string {
+	return p.issuerURL
+}
+
+func (p *KeycloakProvider) DefaultScopes() []string {
+	scopes := []string{"profile", "email"}
+
+	if p.offlineAccess {
+		scopes = append(scopes, "offline_access")
+	}
+
+	return scopes
+}
+
+func (p *KeycloakProvider) LogoutURL() string {
+	return strings.TrimSuffix(p.issuerURL, "/") + "/protocol/openid-connect/logout"
+}
+
+func (p *KeycloakProvider) PostLogin(ctx context.Context, token *oauth2.Token) error {
+	return nil
+}
+
+func (p *KeycloakProvider) MapClaims(claims map[string]interface{}) *Principal {
+	principal := &Principal{}
+
+	if sub, ok := claims["sub"].(string); ok {
+		principal.Subject = sub
+	}
+
+	if email, ok := claims["email"].(string); ok {
+		principal.Email = email
+	}
+
+	principal.Roles = append(principal.Roles, p.realmRoles(claims)...)
+	principal.Roles = append(principal.Roles, p.clientRoles(claims)...)
+
+	return principal
+}
+
+func (p *KeycloakProvider) realmRoles(claims map[string]interface{}) []string {
+	realmAccess, ok := claims["realm_access"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return stringSlice(realmAccess["roles"])
+}
+
+func (p *KeycloakProvider) clientRoles(claims map[string]interface{}) []string {
+	resourceAccess, ok := claims["resource_access"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	client, ok := resourceAccess[p.clientName].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return stringSlice(client["roles"])
+}
+
+func stringSlice(value interface{}) []string {
+	raw, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	roles := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			roles = append(roles, s)
+		}
+	}
+
+	return roles
+}
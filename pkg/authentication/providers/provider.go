@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package providers
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+)
+
+// Principal is the subset of identity the authorization middleware needs,
+// mapped out of whatever claim shape a given provider uses. It's assigned
+// onto the session's authentication.Principal after login.
+type Principal struct {
+	Subject string
+	Email   string
+	Roles   []string
+}
+
+// Provider abstracts the OIDC-provider-specific bits of the login flow:
+// which scopes to request, how to end a session on the IdP side, and how to
+// turn that provider's claim shape into a Principal.
+type Provider interface {
+	// DiscoveryURL returns the issuer URL that OIDC discovery is run against
+	// (the value handed to oidc.NewProvider).
+	DiscoveryURL() string
+	// DefaultScopes returns the scopes requested on top of "openid".
+	DefaultScopes() []string
+	// LogoutURL returns the provider's end-session/revocation endpoint, or
+	// "" if the provider has none and local cookie clearing is sufficient.
+	LogoutURL() string
+	// PostLogin runs any provider-specific step once a token has been
+	// obtained (e.g. nothing for generic OIDC, pulling extra claims for
+	// others).
+	PostLogin(ctx context.Context, token *oauth2.Token) error
+	// MapClaims turns the raw ID token claims into a Principal.
+	MapClaims(claims map[string]interface{}) *Principal
+}
+
+// Name identifiers accepted by authentication.Config.Provider.
+const (
+	Generic  = "generic"
+	Keycloak = "keycloak"
+)
+
+// New returns the Provider implementation selected by name, defaulting to
+// the generic OIDC implementation when name is empty or unrecognized.
+// offlineAccess is only meaningful for providers that support it (Keycloak
+// today); providers without an equivalent concept ignore it.
+func New(name string, issuerURL, clientName string, offlineAccess bool) Provider {
+	switch name {
+	case Keycloak:
+		return NewKeycloakProvider(issuerURL, clientName, WithOfflineAccess(offlineAccess))
+	default:
+		return NewGenericProvider(issuerURL)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package providers
+
+import "testing"
+
+func TestNewKeycloakRequestsOfflineAccessWhenConfigured(t *testing.T) {
+	provider := New(Keycloak, "https://keycloak.example.com/realms/admin", "admin-ui", true)
+
+	scopes := provider.DefaultScopes()
+
+	found := false
+	for _, s := range scopes {
+		if s == "offline_access" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("DefaultScopes() = %v, want offline_access present when offlineAccess=true", scopes)
+	}
+}
+
+func TestNewKeycloakOmitsOfflineAccessWhenNotConfigured(t *testing.T) {
+	provider := New(Keycloak, "https://keycloak.example.com/realms/admin", "admin-ui", false)
+
+	for _, s := range provider.DefaultScopes() {
+		if s == "offline_access" {
+			t.Errorf("DefaultScopes() = %v, want no offline_access when offlineAccess=false", provider.DefaultScopes())
+		}
+	}
+}
+
+func TestNewDefaultsToGenericProvider(t *testing.T) {
+	provider := New("", "https://issuer.example.com", "admin-ui", true)
+
+	if _, ok := provider.(*GenericProvider); !ok {
+		t.Errorf("New(\"\") returned %T, want *GenericProvider", provider)
+	}
+
+	for _, s := range provider.DefaultScopes() {
+		if s == "offline_access" {
+			t.Errorf("GenericProvider.DefaultScopes() = %v, offline_access is Keycloak-specific", provider.DefaultScopes())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RevokedTokenStore tracks tokens that have been explicitly revoked (e.g. via
+// logout) so the authentication middleware can reject them immediately and
+// consistently across replicas, instead of waiting for natural expiry.
+type RevokedTokenStore interface {
+	Revoke(ctx context.Context, tokenID string, expiry time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// InMemoryRevokedTokenStore is the default RevokedTokenStore. It's correct
+// for a single replica only; multi-replica deployments should configure a
+// shared store (e.g. Redis) instead via RevocationStoreDSN.
+type InMemoryRevokedTokenStore struct {
+	mu          sync.RWMutex
+	tokens      map[string]time.Time
+	fallbackTTL time.Duration
+}
+
+// NewInMemoryRevokedTokenStore returns an empty, process-local revoked token
+// store. fallbackTTL is the revocation window used when Revoke is called
+// with a zero Expiry (e.g. a provider response that omits expires_in) - a
+// zero time.Time is already "in the past", so without a fallback the very
+// next IsRevoked lookup would prune the entry and report the token as not
+// revoked.
+func NewInMemoryRevokedTokenStore(fallbackTTL time.Duration) *InMemoryRevokedTokenStore {
+	return &InMemoryRevokedTokenStore{tokens: make(map[string]time.Time), fallbackTTL: fallbackTTL}
+}
+
+// Revoke records tokenID as revoked until expiry, after which it's pruned on
+// the next IsRevoked lookup. A zero expiry falls back to fallbackTTL instead
+// of being treated as already-expired.
+func (s *InMemoryRevokedTokenStore) Revoke(ctx context.Context, tokenID string, expiry time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if expiry.IsZero() {
+		expiry = time.Now().Add(s.fallbackTTL)
+	}
+
+	s.tokens[tokenID] = expiry
+
+	return nil
+}
+
+// IsRevoked reports whether tokenID was revoked and hasn't naturally expired
+// since.
+func (s *InMemoryRevokedTokenStore) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.mu.RLock()
+	expiry, ok := s.tokens[tokenID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	if time.Now().After(expiry) {
+		s.mu.Lock()
+		delete(s.tokens, tokenID)
+		s.mu.Unlock()
+
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInMemoryRevokedTokenStoreRevokeAndCheck(t *testing.T) {
+	store := NewInMemoryRevokedTokenStore(time.Hour)
+	ctx := context.Background()
+
+	revoked, err := store.IsRevoked(ctx, "token-1")
+	if err != nil {
+		t.Fatalf("IsRevoked() error = %v", err)
+	}
+
+	if revoked {
+		t.Error("IsRevoked() = true for a token that was never revoked")
+	}
+
+	if err := store.Revoke(ctx, "token-1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	revoked, err = store.IsRevoked(ctx, "token-1")
+	if err != nil {
+		t.Fatalf("IsRevoked() error = %v", err)
+	}
+
+	if !revoked {
+		t.Error("IsRevoked() = false immediately after Revoke()")
+	}
+}
+
+func TestInMemoryRevokedTokenStorePrunesExpired(t *testing.T) {
+	store := NewInMemoryRevokedTokenStore(time.Hour)
+	ctx := context.Background()
+
+	if err := store.Revoke(ctx, "token-1", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	revoked, err := store.IsRevoked(ctx, "token-1")
+	if err != nil {
+		t.Fatalf("IsRevoked() error = %v", err)
+	}
+
+	if revoked {
+		t.Error("IsRevoked() = true for a token whose revocation window already expired")
+	}
+}
+
+func TestInMemoryRevokedTokenStoreFallsBackToTTLForZeroExpiry(t *testing.T) {
+	store := NewInMemoryRevokedTokenStore(time.Hour)
+	ctx := context.Background()
+
+	if err := store.Revoke(ctx, "token-1", time.Time{}); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	revoked, err := store.IsRevoked(ctx, "token-1")
+	if err != nil {
+		t.Fatalf("IsRevoked() error = %v", err)
+	}
+
+	if !revoked {
+		t.Error("IsRevoked() = false immediately after Revoke() with a zero Expiry; want fallbackTTL to apply")
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInMemoryStaticTokenStoreLookup(t *testing.T) {
+	store := NewInMemoryStaticTokenStore([]StaticToken{
+		{HashedToken: hashToken("ci-token"), Subject: "ci-bot", Roles: []string{"writer"}},
+	})
+
+	principal, ok := store.Lookup(nil, hashToken("ci-token"))
+	if !ok {
+		t.Fatal("Lookup() ok = false, want true for a known token")
+	}
+
+	if principal.Subject != "ci-bot" {
+		t.Errorf("Subject = %q, want %q", principal.Subject, "ci-bot")
+	}
+
+	if _, ok := store.Lookup(nil, hashToken("unknown")); ok {
+		t.Error("Lookup() ok = true, want false for an unknown token")
+	}
+}
+
+func TestBearerTokenExtractsAuthorizationHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/identities", nil)
+	req.Header.Set("Authorization", "Bearer ci-token")
+
+	if got := bearerToken(req); got != "ci-token" {
+		t.Errorf("bearerToken() = %q, want %q", got, "ci-token")
+	}
+}
+
+func TestBearerTokenRejectsNonBearerScheme(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/identities", nil)
+	req.Header.Set("Authorization", "Basic ci-token")
+
+	if got := bearerToken(req); got != "" {
+		t.Errorf("bearerToken() = %q, want empty for a non-Bearer scheme", got)
+	}
+}
+
+func TestBearerTokenRejectsMissingHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/identities", nil)
+
+	if got := bearerToken(req); got != "" {
+		t.Errorf("bearerToken() = %q, want empty without an Authorization header", got)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/canonical/identity-platform-admin-ui/internal/logging"
+	"github.com/canonical/identity-platform-admin-ui/internal/tracing"
+)
+
+// TokenRefresher rotates an access/refresh token pair shortly before the
+// access token expires, so a session stays alive for as long as the refresh
+// token remains valid instead of dying the moment the access token does. It
+// also rejects tokens that have been explicitly revoked (e.g. via logout)
+// before they ever reach a handler, so a revocation takes effect on the very
+// next request instead of waiting for the access token to expire naturally.
+type TokenRefresher struct {
+	oauth2Context OAuth2ContextInterface
+	cookieManager AuthCookieManagerInterface
+	revoked       RevokedTokenStore
+	skew          time.Duration
+	tracer        tracing.TracingInterface
+	logger        logging.LoggerInterface
+}
+
+// NewTokenRefresher returns a TokenRefresher that rejects tokens found in
+// revoked and rotates the rest within skewSeconds of expiry.
+func NewTokenRefresher(oauth2Context OAuth2ContextInterface, cookieManager AuthCookieManagerInterface, revoked RevokedTokenStore, skewSeconds int, tracer tracing.TracingInterface, logger logging.LoggerInterface) *TokenRefresher {
+	return &TokenRefresher{
+		oauth2Context: oauth2Context,
+		cookieManager: cookieManager,
+		revoked:       revoked,
+		skew:          time.Duration(skewSeconds) * time.Second,
+		tracer:        tracer,
+		logger:        logger,
+	}
+}
+
+// needsRefresh reports whether token is within the configured skew window of
+// expiry, or already expired.
+func (r *TokenRefresher) needsRefresh(token *oauth2.Token) bool {
+	if token == nil || token.Expiry.IsZero() {
+		return false
+	}
+
+	return time.Until(token.Expiry) <= r.skew
+}
+
+// Middleware inspects the caller's access token on every request and, once
+// it's within the skew window of expiry, rotates it before the handler runs
+// so the session doesn't drop out from under an in-flight call.
+func (r *TokenRefresher) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, span := r.tracer.Start(req.Context(), "authentication.TokenRefresher.Middleware")
+		defer span.End()
+
+		token, err := r.cookieManager.AuthCookie(req)
+		if err != nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if token != nil && r.revoked != nil {
+			revoked, err := r.revoked.IsRevoked(ctx, token.AccessToken)
+			if err != nil {
+				r.logger.Errorf("failed to check token revocation: %v", err)
+			} else if revoked {
+				r.cookieManager.ClearCookies(w)
+				http.Error(w, "token has been revoked", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		if !r.needsRefresh(token) {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		rotated, err := r.oauth2Context.Config().TokenSource(ctx, &oauth2.Token{RefreshToken: token.RefreshToken}).Token()
+		if err != nil {
+			r.logger.Errorf("failed to refresh access token: %v", err)
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if err := r.cookieManager.SetAuthCookie(w, rotated); err != nil {
+			r.logger.Errorf("failed to persist refreshed access token: %v", err)
+		}
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,20 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openapi
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+// OpenAPIContributor lets an API package fold its own paths, parameters and
+// schemas into the document assembled at startup, instead of hand-rolling
+// validators through validation.Registry.
+//
+// None of identities, clients, idp, schemas, rules, roles or groups
+// implements it, and NewRouter does not build a Registry or install
+// Middleware: RouterConfig has no setting that turns spec-driven validation
+// on, and validation.Registry is the only request validation NewRouter wires
+// up. A Registry with no contributors holds no paths, so its Middleware
+// passes every request through unvalidated.
+type OpenAPIContributor interface {
+	ContributeOpenAPI(doc *openapi3.T) error
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"github.com/canonical/identity-platform-admin-ui/internal/logging"
+	"github.com/canonical/identity-platform-admin-ui/internal/monitoring"
+	"github.com/canonical/identity-platform-admin-ui/internal/problem"
+	"github.com/canonical/identity-platform-admin-ui/internal/tracing"
+)
+
+// Middleware validates incoming requests (path/query params, headers, JSON
+// bodies) against an assembled OpenAPI document before the handler runs.
+type Middleware struct {
+	router  routers.Router
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// NewMiddleware builds the validation middleware from an assembled OpenAPI
+// document, as produced by Registry.Document.
+func NewMiddleware(doc *openapi3.T, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) (*Middleware, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router from document: %w", err)
+	}
+
+	return &Middleware{router: router, tracer: tracer, monitor: monitor, logger: logger}, nil
+}
+
+// Validate is the chi-compatible middleware. Requests that don't match any
+// path in the document are passed through untouched, leaving 404 handling to
+// chi as before.
+func (m *Middleware) Validate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := m.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+		}
+
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			m.writeProblem(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *Middleware) writeProblem(w http.ResponseWriter, r *http.Request, err error) {
+	m.logger.Debugf("openapi: request %s %s failed spec validation: %v", r.Method, r.URL.Path, err)
+
+	problem.Problem{
+		Type:   "about:blank",
+		Title:  "Request failed OpenAPI validation",
+		Status: http.StatusBadRequest,
+		Detail: err.Error(),
+	}.Write(w)
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openapi
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed spec/openapi.yaml
+var baseSpecFS embed.FS
+
+// Registry assembles the single OpenAPI document the admin UI exposes from
+// the embedded base document plus whatever each API package contributes.
+type Registry struct {
+	doc *openapi3.T
+}
+
+// NewRegistry loads the embedded base spec (info, shared schemas) that every
+// contributed path gets folded into.
+func NewRegistry() (*Registry, error) {
+	data, err := baseSpecFS.ReadFile("spec/openapi.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded OpenAPI base spec: %w", err)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse embedded OpenAPI base spec: %w", err)
+	}
+
+	return &Registry{doc: doc}, nil
+}
+
+// Add folds a contributor's paths/schemas into the document. It's meant to
+// be called once per API package at startup, before Document is read.
+func (r *Registry) Add(contributor OpenAPIContributor) error {
+	return contributor.ContributeOpenAPI(r.doc)
+}
+
+// Document returns the assembled OpenAPI document.
+func (r *Registry) Document() *openapi3.T {
+	return r.doc
+}
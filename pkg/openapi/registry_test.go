@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+type stubContributor struct {
+	path string
+	err  error
+}
+
+func (c *stubContributor) ContributeOpenAPI(doc *openapi3.T) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	if doc.Paths == nil {
+		doc.Paths = openapi3.NewPaths()
+	}
+
+	doc.Paths.Set(c.path, &openapi3.PathItem{})
+
+	return nil
+}
+
+func TestRegistryAddFoldsContributorPaths(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	if err := registry.Add(&stubContributor{path: "/api/v0/widgets"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if registry.Document().Paths == nil || registry.Document().Paths.Find("/api/v0/widgets") == nil {
+		t.Error("Document() does not contain the path contributed by Add()")
+	}
+}
+
+func TestRegistryAddPropagatesContributorError(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	wantErr := &stubContributor{err: errors.New("contributor failed")}
+
+	if err := registry.Add(wantErr); err == nil {
+		t.Error("Add() error = nil, want non-nil when contributor fails")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/cors"
+)
+
+// CORSConfig describes the cross-origin policy enforced on the API router.
+// AllowedOrigins entries support a leading wildcard suffix (e.g. "*.example.com")
+// so a single entry can cover every subdomain of a trusted parent domain, in
+// addition to the literal "*" for "allow any origin".
+//
+// This is library support only: like RouterConfig's other per-feature
+// settings (idp, schemas, oauth2, ...), a CORSConfig is meant to be built by
+// the application's config loader from whatever origins/headers an operator
+// configures, then passed into NewRouterConfig. No config loader lives in
+// this module yet, so that wiring - reading an env var or config file into
+// NewCORSConfig/ParseAllowedOrigins - is left to whatever composes
+// NewRouterConfig; nothing here reads the environment.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// NewCORSConfig returns a CORSConfig built from the given origin allowlist and
+// policy knobs. Passing a nil/empty allowedMethods or allowedHeaders falls
+// back to a permissive default set covering the API's supported verbs.
+func NewCORSConfig(allowedOrigins, allowedMethods, allowedHeaders, exposedHeaders []string, allowCredentials bool, maxAge time.Duration) *CORSConfig {
+	return &CORSConfig{
+		AllowedOrigins:   allowedOrigins,
+		AllowedMethods:   allowedMethods,
+		AllowedHeaders:   allowedHeaders,
+		ExposedHeaders:   exposedHeaders,
+		AllowCredentials: allowCredentials,
+		MaxAge:           maxAge,
+	}
+}
+
+// DefaultCORSConfig preserves the previous behavior (any origin, no
+// credentials) for deployments that don't configure CORS explicitly. It's
+// exported so the application config loader has a concrete fallback to
+// return when no origin allowlist is set, instead of passing a nil
+// *CORSConfig through and relying on middlewareCORS to guess.
+func DefaultCORSConfig() *CORSConfig {
+	return NewCORSConfig([]string{"*"}, nil, nil, nil, false, 0)
+}
+
+// ParseAllowedOrigins splits a comma-separated list of origins (as typically
+// read from a single config/env entry) into the slice NewCORSConfig expects,
+// trimming whitespace and dropping empty entries.
+func ParseAllowedOrigins(raw string) []string {
+	fields := strings.Split(raw, ",")
+	origins := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		if f := strings.TrimSpace(f); f != "" {
+			origins = append(origins, f)
+		}
+	}
+
+	return origins
+}
+
+// middlewareCORS builds the CORS middleware honoring the allowlist and
+// credentials settings in config. When config is nil it falls back to
+// DefaultCORSConfig so callers don't have to nil-check before wiring it up.
+func middlewareCORS(config *CORSConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = DefaultCORSConfig()
+	}
+
+	allowedMethods := config.AllowedMethods
+	if len(allowedMethods) == 0 {
+		allowedMethods = []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+			http.MethodOptions,
+		}
+	}
+
+	allowedHeaders := config.AllowedHeaders
+	if len(allowedHeaders) == 0 {
+		allowedHeaders = []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"}
+	}
+
+	return cors.Handler(cors.Options{
+		// go-chi/cors resolves a wildcard suffix (e.g. "https://*.example.com")
+		// against the request Origin and, when AllowCredentials is set, echoes
+		// back the matched origin instead of "*" as required by the CORS spec.
+		AllowedOrigins:   config.AllowedOrigins,
+		AllowedMethods:   allowedMethods,
+		AllowedHeaders:   allowedHeaders,
+		ExposedHeaders:   config.ExposedHeaders,
+		AllowCredentials: config.AllowCredentials,
+		MaxAge:           int(config.MaxAge.Seconds()),
+	})
+}
@@ -5,6 +5,7 @@ package web
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/go-chi/chi/v5"
@@ -12,11 +13,13 @@ import (
 
 	"github.com/canonical/identity-platform-admin-ui/internal/authorization"
 	"github.com/canonical/identity-platform-admin-ui/internal/logging"
+	"github.com/canonical/identity-platform-admin-ui/internal/middleware/timeout"
 	"github.com/canonical/identity-platform-admin-ui/internal/monitoring"
 	"github.com/canonical/identity-platform-admin-ui/internal/pool"
 	"github.com/canonical/identity-platform-admin-ui/internal/tracing"
 	"github.com/canonical/identity-platform-admin-ui/internal/validation"
 	"github.com/canonical/identity-platform-admin-ui/pkg/authentication"
+	"github.com/canonical/identity-platform-admin-ui/pkg/authentication/providers"
 	"github.com/canonical/identity-platform-admin-ui/pkg/clients"
 	"github.com/canonical/identity-platform-admin-ui/pkg/groups"
 	"github.com/canonical/identity-platform-admin-ui/pkg/identities"
@@ -37,11 +40,19 @@ type RouterConfig struct {
 	rules                    *rules.Config
 	ui                       *ui.Config
 	external                 ExternalClientsConfigInterface
+	authorizerChain          *authorization.Chain
 	oauth2                   *authentication.Config
 	olly                     O11yConfigInterface
+	cors                     *CORSConfig
+	requestTimeout           time.Duration
+	requestTimeoutOverrides  map[string]time.Duration
 }
 
-func NewRouterConfig(contextPath string, payloadValidationEnabled bool, idp *idp.Config, schemas *schemas.Config, rules *rules.Config, ui *ui.Config, external ExternalClientsConfigInterface, oauth2 *authentication.Config, olly O11yConfigInterface) *RouterConfig {
+// NewRouterConfig wires up a RouterConfig. authorizerChain is optional: pass
+// the *authorization.Chain a deployment wants hot enable/disable over, or nil
+// to fall back to whatever external.Authorizer() returns (hot enable/disable
+// is only available when that happens to be a *authorization.Chain too).
+func NewRouterConfig(contextPath string, payloadValidationEnabled bool, idp *idp.Config, schemas *schemas.Config, rules *rules.Config, ui *ui.Config, external ExternalClientsConfigInterface, authorizerChain *authorization.Chain, oauth2 *authentication.Config, olly O11yConfigInterface, cors *CORSConfig, requestTimeout time.Duration, requestTimeoutOverrides map[string]time.Duration) *RouterConfig {
 	return &RouterConfig{
 		contextPath:              contextPath,
 		payloadValidationEnabled: payloadValidationEnabled,
@@ -50,11 +61,32 @@ func NewRouterConfig(contextPath string, payloadValidationEnabled bool, idp *idp
 		rules:                    rules,
 		ui:                       ui,
 		external:                 external,
+		authorizerChain:          authorizerChain,
 		oauth2:                   oauth2,
 		olly:                     olly,
+		cors:                     cors,
+		requestTimeout:           requestTimeout,
+		requestTimeoutOverrides:  requestTimeoutOverrides,
 	}
 }
 
+// resolveAuthorizerChain picks the *authorization.Chain to expose hot
+// enable/disable over: the explicitly configured one if there is one,
+// otherwise authorizer itself if the config layer's Authorizer()
+// implementation happens to be a *authorization.Chain. Returns nil - meaning
+// the admin endpoints stay unregistered - only when neither applies.
+func resolveAuthorizerChain(explicit *authorization.Chain, authorizer authorization.Authorizer) *authorization.Chain {
+	if explicit != nil {
+		return explicit
+	}
+
+	if chain, ok := authorizer.(*authorization.Chain); ok {
+		return chain
+	}
+
+	return nil
+}
+
 func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handler {
 	router := chi.NewMux()
 
@@ -74,9 +106,26 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 		middlewares,
 		middleware.RequestID,
 		monitoring.NewMiddleware(monitor, logger).ResponseTime(),
-		middlewareCORS([]string{"*"}),
+		middlewareCORS(config.cors),
 	)
-	authorizationMiddleware := authorization.NewMiddleware(config.external.Authorizer(), monitor, logger).Authorize()
+	authorizer := config.external.Authorizer()
+
+	// The *authorization.Chain that hot enable/disable operates on is
+	// resolved from config.authorizerChain rather than asserting the type of
+	// external.Authorizer(), so a deployment gets working hot enable/disable
+	// by passing a Chain to NewRouterConfig regardless of what concrete type
+	// the config layer's Authorizer() happens to return.
+	authorizerChain := resolveAuthorizerChain(config.authorizerChain, authorizer)
+
+	// authorizationMiddleware must consult the very same Authorizer the admin
+	// endpoints below mutate, or disabling a plugin through them would keep
+	// being silently ignored on every request. Prefer the resolved chain over
+	// authorizer itself whenever the two could be different objects.
+	var enforcedAuthorizer authorization.Authorizer = authorizer
+	if authorizerChain != nil {
+		enforcedAuthorizer = authorizerChain
+	}
+	authorizationMiddleware := authorization.NewMiddleware(enforcedAuthorizer, monitor, logger).Authorize()
 
 	// TODO @shipperizer add a proper configuration to enable http logger middleware as it's expensive
 	if true {
@@ -91,41 +140,53 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 	statusAPI := status.NewAPI(tracer, monitor, logger)
 	metricsAPI := metrics.NewAPI(logger)
 
+	// The identities, clients, idp, schemas and rules services are built from
+	// enforcedAuthorizer, the same Authorizer instance authorizationMiddleware
+	// and the admin enable/disable endpoints operate on - not a fresh
+	// externalConfig.Authorizer() call per service - so a plugin disabled
+	// through the admin API stops being consulted here too, instead of only at
+	// the top-level middleware. The roles and groups services take
+	// externalConfig.OpenFGA() directly and do not go through the chain.
 	identitiesAPI := identities.NewAPI(
-		identities.NewService(externalConfig.KratosAdmin().IdentityAPI(), externalConfig.Authorizer(), tracer, monitor, logger),
+		identities.NewService(externalConfig.KratosAdmin().IdentityAPI(), enforcedAuthorizer, tracer, monitor, logger),
 		tracer,
 		monitor,
 		logger,
 	)
 
 	clientsAPI := clients.NewAPI(
-		clients.NewService(externalConfig.HydraAdmin(), externalConfig.Authorizer(), tracer, monitor, logger),
+		clients.NewService(externalConfig.HydraAdmin(), enforcedAuthorizer, tracer, monitor, logger),
 		tracer,
 		monitor,
 		logger,
 	)
 
 	idpAPI := idp.NewAPI(
-		idp.NewService(idpConfig, externalConfig.Authorizer(), tracer, monitor, logger),
+		idp.NewService(idpConfig, enforcedAuthorizer, tracer, monitor, logger),
 		tracer,
 		monitor,
 		logger,
 	)
 
 	schemasAPI := schemas.NewAPI(
-		schemas.NewService(schemasConfig, externalConfig.Authorizer(), tracer, monitor, logger),
+		schemas.NewService(schemasConfig, enforcedAuthorizer, tracer, monitor, logger),
 		tracer,
 		monitor,
 		logger,
 	)
 
 	rulesAPI := rules.NewAPI(
-		rules.NewService(rulesConfig, externalConfig.Authorizer(), tracer, monitor, logger),
+		rules.NewService(rulesConfig, enforcedAuthorizer, tracer, monitor, logger),
 		tracer,
 		monitor,
 		logger,
 	)
 
+	// roles/groups queue work onto wpool directly, outside the per-request
+	// timeout context set up below; pool.Pool (internal/pool) drops a queued
+	// job once the context it was submitted with is cancelled, so the
+	// request's timeout bounds the queued work too, not just the handler
+	// that enqueued it.
 	rolesAPI := roles.NewAPI(
 		roles.NewService(externalConfig.OpenFGA(), wpool, tracer, monitor, logger),
 		tracer,
@@ -145,11 +206,27 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 	// Create a new router for the API so that we can add extra middlewares
 	apiRouter := router.Group(nil).(*chi.Mux)
 
+	defaultTimeout := config.requestTimeout
+	if defaultTimeout == 0 {
+		defaultTimeout = 30 * time.Second
+	}
+	// Bound every request so a stuck Kratos/Hydra/OpenFGA call can't tie up a
+	// worker indefinitely; per-path-prefix overrides let slow bulk endpoints
+	// (e.g. schemas) run longer and cheap ones (e.g. status) fail fast.
+	// Registered before the authentication/authorization middlewares below so
+	// it wraps the whole chain, including the refresh-token exchange call to
+	// the IdP - the exact kind of upstream call this is meant to bound.
+	apiRouter.Use(timeout.Timeout(defaultTimeout, config.requestTimeoutOverrides))
+
 	var oauth2Context authentication.OAuth2ContextInterface
 	var cookieManager authentication.AuthCookieManagerInterface
+	var revokedTokenStore authentication.RevokedTokenStore
 
 	if oauth2Config.Enabled {
-		oauth2Context = authentication.NewOAuth2Context(config.oauth2, oidc.NewProvider, tracer, logger, monitor)
+		// oauth2Config.Provider selects the vendor-specific behavior (scopes,
+		// logout endpoint, claim mapping); "" falls back to generic OIDC.
+		identityProvider := providers.New(oauth2Config.Provider, oauth2Config.IssuerURL, oauth2Config.ClientID, oauth2Config.OfflineAccess)
+		oauth2Context = authentication.NewOAuth2Context(config.oauth2, identityProvider, oidc.NewProvider, tracer, logger, monitor)
 		encrypt := authentication.NewEncrypt([]byte(oauth2Config.CookiesEncryptionKey), logger, tracer)
 		cookieManager = authentication.NewAuthCookieManager(
 			oauth2Config.AuthCookieTTLSeconds,
@@ -158,6 +235,18 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 			logger,
 		)
 
+		// RevocationStoreDSN selects a shared backend for multi-replica
+		// deployments; today only the single-replica in-memory store is
+		// implemented. Log loudly when a DSN is configured but can't be
+		// honored, rather than silently falling back to a store that doesn't
+		// share revocations across replicas - the exact failure mode the DSN
+		// is meant to prevent.
+		if oauth2Config.RevocationStoreDSN != "" {
+			logger.Errorf("RevocationStoreDSN is set but no shared RevokedTokenStore backend is implemented; falling back to the single-replica in-memory store, so revocations won't propagate across replicas")
+		}
+		revokedTokenStore = authentication.NewInMemoryRevokedTokenStore(time.Duration(oauth2Config.UserSessionCookieTTLSeconds) * time.Second)
+		tokenRefresher := authentication.NewTokenRefresher(oauth2Context, cookieManager, revokedTokenStore, oauth2Config.RefreshSkewSeconds, tracer, logger)
+
 		authenticationMiddleware := authentication.NewAuthenticationMiddleware(oauth2Context, cookieManager, tracer, logger)
 		authenticationMiddleware.SetAllowListedEndpoints(
 			"/api/v0/auth",
@@ -165,13 +254,30 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 			"/api/v0/status",
 			"/api/v0/metrics",
 		)
-		apiRouter.Use(authenticationMiddleware.OAuth2AuthenticationChain()...)
+		oauth2Chain := chi.Middlewares(authenticationMiddleware.OAuth2AuthenticationChain())
+
+		if len(oauth2Config.StaticTokens) > 0 {
+			// Machine clients (CI jobs, operators) authenticate with a static
+			// bearer token and skip the interactive OAuth2 dance entirely;
+			// anything else falls back to the cookie/bearer flow below.
+			staticStore := authentication.NewInMemoryStaticTokenStore(oauth2Config.StaticTokens)
+			staticAuthenticator := authentication.NewStaticCredentialAuthenticator(staticStore, tracer, logger)
+			authenticationChain := authentication.NewAuthenticationChain(staticAuthenticator)
+
+			apiRouter.Use(authenticationChain.Wrap(oauth2Chain.Handler))
+		} else {
+			apiRouter.Use(oauth2Chain...)
+		}
+
+		// Rotate tokens close to expiry before the handler runs, so an
+		// in-flight request never drops out from under a long admin session.
+		apiRouter.Use(tokenRefresher.Middleware)
 	}
 
 	// register authorizationMiddleware after authentication so Principal is available if necessary
 	apiRouter.Use(authorizationMiddleware)
 
-	if config.payloadValidationEnabled {
+	registerPayloadValidation := func() {
 		validationRegistry := validation.NewRegistry(tracer, monitor, logger)
 		apiRouter.Use(validationRegistry.ValidationMiddleware)
 
@@ -184,6 +290,16 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 		groupsAPI.RegisterValidation(validationRegistry)
 	}
 
+	// openapi.Registry/Middleware exist as infrastructure for spec-driven
+	// validation, but no API package implements OpenAPIContributor (see the
+	// doc comment on that interface), so turning validation on would always
+	// assemble an empty document and validate nothing. Not wired into
+	// RouterConfig until at least one real contributor lands; hand-written
+	// validation.Registry remains the only request validation path.
+	if config.payloadValidationEnabled {
+		registerPayloadValidation()
+	}
+
 	// register endpoints as last step
 	statusAPI.RegisterEndpoints(apiRouter)
 	metricsAPI.RegisterEndpoints(apiRouter)
@@ -196,6 +312,13 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 	rolesAPI.RegisterEndpoints(apiRouter)
 	groupsAPI.RegisterEndpoints(apiRouter)
 
+	if authorizerChain != nil {
+		// Enable/disable plus a GET for reading back which plugins are
+		// currently live - see AdminAPI's doc comment for why that read path
+		// lives here instead of pkg/status.
+		authorization.NewAdminAPI(authorizerChain).RegisterEndpoints(apiRouter)
+	}
+
 	if oauth2Config.Enabled {
 
 		login := authentication.NewAPI(
@@ -207,6 +330,9 @@ func NewRouter(config *RouterConfig, wpool pool.WorkerPoolInterface) http.Handle
 			logger,
 		)
 		login.RegisterEndpoints(apiRouter)
+
+		logoutHandler := authentication.NewLogoutHandler(oauth2Context, cookieManager, revokedTokenStore, tracer, logger)
+		apiRouter.Post("/api/v0/auth/logout", logoutHandler.ServeHTTP)
 	}
 
 	uiAPI.RegisterEndpoints(router)
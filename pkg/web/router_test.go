@@ -0,0 +1,40 @@
+// Copyright 2024 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package web
+
+import (
+	"context"
+	"testing"
+
+	"github.com/canonical/identity-platform-admin-ui/internal/authorization"
+)
+
+type stubAuthorizer struct{}
+
+func (s *stubAuthorizer) Check(ctx context.Context, subject, relation, object string) (bool, error) {
+	return true, nil
+}
+
+func TestResolveAuthorizerChainPrefersExplicitConfig(t *testing.T) {
+	explicit := authorization.NewChain(nil, nil)
+	fromAuthorizer := authorization.NewChain(nil, nil)
+
+	if got := resolveAuthorizerChain(explicit, fromAuthorizer); got != explicit {
+		t.Error("resolveAuthorizerChain() did not prefer the explicitly configured chain")
+	}
+}
+
+func TestResolveAuthorizerChainFallsBackToAuthorizerType(t *testing.T) {
+	fromAuthorizer := authorization.NewChain(nil, nil)
+
+	if got := resolveAuthorizerChain(nil, fromAuthorizer); got != fromAuthorizer {
+		t.Error("resolveAuthorizerChain() did not fall back to an *authorization.Chain Authorizer")
+	}
+}
+
+func TestResolveAuthorizerChainNilWhenNeitherIsAChain(t *testing.T) {
+	if got := resolveAuthorizerChain(nil, &stubAuthorizer{}); got != nil {
+		t.Errorf("resolveAuthorizerChain() = %v, want nil when no chain is available", got)
+	}
+}